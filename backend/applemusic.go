@@ -0,0 +1,88 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// appleMusicProvider fetches Apple Music's public per-country "Top 100:
+// Most Played" RSS feed, published as JSON.
+type appleMusicProvider struct{}
+
+func newAppleMusicProvider() *appleMusicProvider { return &appleMusicProvider{} }
+
+func (p *appleMusicProvider) Meta() ChartMeta {
+	return ChartMeta{
+		ID:                "apple-music-top-100",
+		Name:              "Apple Music Top 100",
+		Frequency:         "daily",
+		SupportsDateRange: false,
+		Kind:              ChartKindSingles,
+	}
+}
+
+type appleMusicFeedResponse struct {
+	Feed struct {
+		Results []struct {
+			Name      string `json:"name"`
+			ArtistName string `json:"artistName"`
+			AlbumName string `json:"albumName"`
+		} `json:"results"`
+	} `json:"feed"`
+}
+
+func (p *appleMusicProvider) Fetch(ctx context.Context, params ChartFetchParams) (*Chart, error) {
+	country := strings.ToLower(params.Country)
+	if country == "" {
+		country = "us"
+	}
+
+	url := fmt.Sprintf("https://rss.applemarketingtools.com/api/v2/%s/music/most-played/100/songs.json", country)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Apple Music chart: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Apple Music returned status %d", resp.StatusCode)
+	}
+
+	var feed appleMusicFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse Apple Music chart: %w", err)
+	}
+
+	results := feed.Feed.Results
+	if params.Limit > 0 && params.Limit < len(results) {
+		results = results[:params.Limit]
+	}
+
+	entries := make([]ChartEntry, len(results))
+	for i, r := range results {
+		entries[i] = ChartEntry{
+			Rank:   i + 1,
+			Title:  r.Name,
+			Artist: r.ArtistName,
+			Album:  r.AlbumName,
+		}
+	}
+
+	return &Chart{
+		ProviderID: p.Meta().ID,
+		Date:       time.Now().Format("2006-01-02"),
+		Kind:       ChartKindSingles,
+		Entries:    entries,
+	}, nil
+}
@@ -0,0 +1,408 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// ArchiveStore persists every fetched BillboardChart keyed by
+// (chart_id, date), so a chart only has to be fetched once.
+// NewFileArchiveStore persists to a single JSON file, rewritten whole on
+// every Put; NewSQLiteArchiveStore is the SQLite-backed default for
+// archives too large for that to stay cheap. Any store can satisfy the
+// interface.
+type ArchiveStore interface {
+	Get(chartID, date string) (*BillboardChart, bool, error)
+	Put(chartID, date string, chart *BillboardChart) error
+	// Dates returns every date stored for chartID, ascending.
+	Dates(chartID string) ([]string, error)
+}
+
+type fileArchiveStore struct {
+	mu   sync.Mutex
+	path string
+	// data maps chart_id -> date -> chart.
+	data map[string]map[string]*BillboardChart
+}
+
+// NewFileArchiveStore opens (creating if necessary) a JSON-file-backed
+// ArchiveStore at path.
+func NewFileArchiveStore(path string) (ArchiveStore, error) {
+	store := &fileArchiveStore{path: path, data: make(map[string]map[string]*BillboardChart)}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read archive file: %w", err)
+	}
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &store.data); err != nil {
+			return nil, fmt.Errorf("failed to parse archive file: %w", err)
+		}
+	}
+
+	return store, nil
+}
+
+func (s *fileArchiveStore) Get(chartID, date string) (*BillboardChart, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charts, ok := s.data[chartID]
+	if !ok {
+		return nil, false, nil
+	}
+	chart, ok := charts[date]
+	return chart, ok, nil
+}
+
+func (s *fileArchiveStore) Put(chartID, date string, chart *BillboardChart) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data[chartID] == nil {
+		s.data[chartID] = make(map[string]*BillboardChart)
+	}
+	s.data[chartID][date] = chart
+
+	body, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := os.WriteFile(s.path, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write archive file: %w", err)
+	}
+	return nil
+}
+
+func (s *fileArchiveStore) Dates(chartID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charts, ok := s.data[chartID]
+	if !ok {
+		return nil, nil
+	}
+	dates := make([]string, 0, len(charts))
+	for date := range charts {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+// sqliteArchiveStore persists archived charts to a SQLite database, one row
+// per (chart_id, date), so Put is a single upsert rather than
+// fileArchiveStore's whole-file rewrite.
+type sqliteArchiveStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteArchiveStore opens (creating if necessary) a SQLite-backed
+// ArchiveStore at path.
+func NewSQLiteArchiveStore(path string) (ArchiveStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive database: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS archived_charts (
+		chart_id TEXT NOT NULL,
+		date     TEXT NOT NULL,
+		chart    TEXT NOT NULL,
+		PRIMARY KEY (chart_id, date)
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create archive schema: %w", err)
+	}
+
+	return &sqliteArchiveStore{db: db}, nil
+}
+
+func (s *sqliteArchiveStore) Get(chartID, date string) (*BillboardChart, bool, error) {
+	var body string
+	err := s.db.QueryRow(`SELECT chart FROM archived_charts WHERE chart_id = ? AND date = ?`, chartID, date).Scan(&body)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query archive: %w", err)
+	}
+
+	var chart BillboardChart
+	if err := json.Unmarshal([]byte(body), &chart); err != nil {
+		return nil, false, fmt.Errorf("failed to decode archived chart: %w", err)
+	}
+	return &chart, true, nil
+}
+
+func (s *sqliteArchiveStore) Put(chartID, date string, chart *BillboardChart) error {
+	body, err := json.Marshal(chart)
+	if err != nil {
+		return fmt.Errorf("failed to encode chart: %w", err)
+	}
+
+	_, err = s.db.Exec(`INSERT INTO archived_charts (chart_id, date, chart) VALUES (?, ?, ?)
+		ON CONFLICT (chart_id, date) DO UPDATE SET chart = excluded.chart`, chartID, date, string(body))
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+func (s *sqliteArchiveStore) Dates(chartID string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT date FROM archived_charts WHERE chart_id = ? ORDER BY date ASC`, chartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive dates: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []string
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("failed to scan archive date: %w", err)
+		}
+		dates = append(dates, date)
+	}
+	return dates, rows.Err()
+}
+
+// billboardChartSlug resolves a registered Billboard-family ChartProvider ID
+// to its billboard.com URL slug, reusing the slug each provider already
+// carries so SyncArchive can't drift out of sync with DefaultChartRegistry.
+func billboardChartSlug(chartID string) (string, bool) {
+	provider, ok := DefaultChartRegistry.Get(chartID)
+	if !ok {
+		return "", false
+	}
+	bp, ok := provider.(*billboardProvider)
+	if !ok {
+		return "", false
+	}
+	return bp.slug, true
+}
+
+// SyncArchive walks every Saturday between from and to (inclusive),
+// fetching any week not already present in store with bounded concurrency
+// and a polite delay between requests, and returns how many new weeks were
+// fetched.
+func SyncArchive(ctx context.Context, store ArchiveStore, chartID string, from, to time.Time) (int, error) {
+	slug, ok := billboardChartSlug(chartID)
+	if !ok {
+		return 0, fmt.Errorf("unknown Billboard chart id %q", chartID)
+	}
+
+	var missing []string
+	for d := alignToSaturday(from); !d.After(to); d = d.AddDate(0, 0, 7) {
+		date := d.Format("2006-01-02")
+		if _, ok, err := store.Get(chartID, date); err != nil {
+			return 0, err
+		} else if !ok {
+			missing = append(missing, date)
+		}
+	}
+
+	const maxConcurrent = 3
+	limiter := newRateLimiter(2 * time.Second)
+	sem := make(chan struct{}, maxConcurrent)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fetched  int
+		firstErr error
+	)
+
+	for _, date := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(date string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := limiter.wait(ctx); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+
+			chart, err := fetchBillboardChart(ctx, slug, date)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("fetch %s %s: %w", chartID, date, err)
+				}
+				mu.Unlock()
+				return
+			}
+
+			if err := store.Put(chartID, date, chart); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+
+			mu.Lock()
+			fetched++
+			mu.Unlock()
+		}(date)
+	}
+
+	wg.Wait()
+	return fetched, firstErr
+}
+
+// alignToSaturday returns the first Saturday on or after t.
+func alignToSaturday(t time.Time) time.Time {
+	daysUntilSaturday := (int(time.Saturday) - int(t.Weekday()) + 7) % 7
+	return t.AddDate(0, 0, daysUntilSaturday)
+}
+
+// ChartMove describes one entry's rank change between two chart snapshots.
+type ChartMove struct {
+	Entry        BillboardEntry
+	PreviousRank int
+	CurrentRank  int
+	// Delta is positive when the entry moved up the chart (lower rank
+	// number) and negative when it moved down.
+	Delta int
+}
+
+// ChartDiff is the result of comparing two BillboardChart snapshots.
+type ChartDiff struct {
+	New             []BillboardEntry
+	Dropped         []BillboardEntry
+	Returning       []BillboardEntry
+	BiggestGainers  []ChartMove
+	BiggestDroppers []ChartMove
+}
+
+const maxChartMovers = 10
+
+// DiffCharts compares two BillboardChart snapshots (typically consecutive
+// weeks from an ArchiveStore) and reports what's new, what dropped off,
+// what re-entered, and the biggest movers in both directions.
+func DiffCharts(a, b *BillboardChart) ChartDiff {
+	aByKey := make(map[string]BillboardEntry, len(a.Entries))
+	for _, e := range a.Entries {
+		aByKey[chartEntryKey(e)] = e
+	}
+	bByKey := make(map[string]BillboardEntry, len(b.Entries))
+	for _, e := range b.Entries {
+		bByKey[chartEntryKey(e)] = e
+	}
+
+	var diff ChartDiff
+	var moves []ChartMove
+
+	for key, entry := range bByKey {
+		prev, ok := aByKey[key]
+		if !ok {
+			if entry.IsReturning {
+				diff.Returning = append(diff.Returning, entry)
+			} else {
+				diff.New = append(diff.New, entry)
+			}
+			continue
+		}
+		moves = append(moves, ChartMove{
+			Entry:        entry,
+			PreviousRank: prev.Rank,
+			CurrentRank:  entry.Rank,
+			Delta:        prev.Rank - entry.Rank,
+		})
+	}
+
+	for key, entry := range aByKey {
+		if _, ok := bByKey[key]; !ok {
+			diff.Dropped = append(diff.Dropped, entry)
+		}
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Delta > moves[j].Delta })
+	for i, m := range moves {
+		if i >= maxChartMovers || m.Delta <= 0 {
+			break
+		}
+		diff.BiggestGainers = append(diff.BiggestGainers, m)
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return moves[i].Delta < moves[j].Delta })
+	for i, m := range moves {
+		if i >= maxChartMovers || m.Delta >= 0 {
+			break
+		}
+		diff.BiggestDroppers = append(diff.BiggestDroppers, m)
+	}
+
+	return diff
+}
+
+func chartEntryKey(e BillboardEntry) string {
+	return strings.ToLower(e.Title) + "|" + strings.ToLower(e.Artist)
+}
+
+// ChartAppearance is one week a track appeared on a chart, returned by
+// TrackHistory.
+type ChartAppearance struct {
+	Date         string
+	Rank         int
+	PeakRank     int
+	WeeksOnChart int
+}
+
+// TrackHistory returns every archived appearance of (title, artist) on
+// chartID, ordered by date, enabling longitudinal queries like "how long
+// was this song on the chart" across a synced ArchiveStore.
+func TrackHistory(store ArchiveStore, chartID, title, artist string) ([]ChartAppearance, error) {
+	dates, err := store.Dates(chartID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := chartEntryKey(BillboardEntry{Title: title, Artist: artist})
+
+	var appearances []ChartAppearance
+	for _, date := range dates {
+		chart, ok, err := store.Get(chartID, date)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		for _, e := range chart.Entries {
+			if chartEntryKey(e) == key {
+				appearances = append(appearances, ChartAppearance{
+					Date:         date,
+					Rank:         e.Rank,
+					PeakRank:     e.PeakRank,
+					WeeksOnChart: e.WeeksOnChart,
+				})
+				break
+			}
+		}
+	}
+
+	return appearances, nil
+}
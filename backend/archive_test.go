@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlignToSaturday(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "already a Saturday", in: "2024-01-06", want: "2024-01-06"},
+		{name: "Sunday rolls forward to next Saturday", in: "2024-01-07", want: "2024-01-13"},
+		{name: "Monday rolls forward to the same week's Saturday", in: "2024-01-08", want: "2024-01-13"},
+		{name: "Friday rolls forward one day", in: "2024-01-12", want: "2024-01-13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := time.Parse("2006-01-02", tt.in)
+			if err != nil {
+				t.Fatalf("failed to parse fixture date: %v", err)
+			}
+			want, err := time.Parse("2006-01-02", tt.want)
+			if err != nil {
+				t.Fatalf("failed to parse fixture date: %v", err)
+			}
+			if got := alignToSaturday(in); !got.Equal(want) {
+				t.Errorf("alignToSaturday(%s) = %s, want %s", tt.in, got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffCharts(t *testing.T) {
+	previous := &BillboardChart{
+		Date: "2024-01-06",
+		Entries: []BillboardEntry{
+			{Rank: 2, Title: "Staying Power", Artist: "Nova Tide"},
+			{Rank: 3, Title: "Fading Out", Artist: "Gray Static"},
+		},
+	}
+	current := &BillboardChart{
+		Date: "2024-01-13",
+		Entries: []BillboardEntry{
+			{Rank: 2, Title: "Gold Rush", Artist: "Nova Tide"},
+			{Rank: 1, Title: "Staying Power", Artist: "Nova Tide"},
+			{Rank: 3, Title: "Old Flame", Artist: "Harbor Lights", IsReturning: true},
+		},
+	}
+
+	diff := DiffCharts(previous, current)
+
+	if len(diff.New) != 1 || diff.New[0].Title != "Gold Rush" {
+		t.Errorf("DiffCharts().New = %+v, want a single entry for Gold Rush", diff.New)
+	}
+	if len(diff.Dropped) != 1 || diff.Dropped[0].Title != "Fading Out" {
+		t.Errorf("DiffCharts().Dropped = %+v, want a single entry for Fading Out", diff.Dropped)
+	}
+	if len(diff.Returning) != 1 || diff.Returning[0].Title != "Old Flame" {
+		t.Errorf("DiffCharts().Returning = %+v, want a single entry for Old Flame", diff.Returning)
+	}
+
+	if len(diff.BiggestGainers) != 1 || diff.BiggestGainers[0].Entry.Title != "Staying Power" {
+		t.Errorf("DiffCharts().BiggestGainers = %+v, want a single mover for Staying Power", diff.BiggestGainers)
+	}
+	if got := diff.BiggestGainers[0].Delta; got != 1 {
+		t.Errorf("BiggestGainers[0].Delta = %d, want 1", got)
+	}
+}
+
+func TestDiffChartsNoOverlap(t *testing.T) {
+	previous := &BillboardChart{Entries: []BillboardEntry{{Rank: 1, Title: "Fading Out", Artist: "Gray Static"}}}
+	current := &BillboardChart{Entries: []BillboardEntry{{Rank: 1, Title: "Gold Rush", Artist: "Nova Tide"}}}
+
+	diff := DiffCharts(previous, current)
+
+	if len(diff.New) != 1 || len(diff.Dropped) != 1 {
+		t.Errorf("DiffCharts() = %+v, want exactly one new and one dropped entry", diff)
+	}
+	if len(diff.BiggestGainers) != 0 || len(diff.BiggestDroppers) != 0 {
+		t.Errorf("DiffCharts() movers = gainers:%+v droppers:%+v, want none with no overlapping entries", diff.BiggestGainers, diff.BiggestDroppers)
+	}
+}
@@ -0,0 +1,244 @@
+package backend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// BillboardParser turns a Billboard chart page's HTML into chart entries.
+// The default implementation walks the DOM rather than matching against
+// fixed positions, so it survives Billboard reordering or hiding stat
+// columns week to week. Alternative sources (archive.org snapshots, JSON
+// API mirrors) can implement this interface and be swapped in via
+// SetBillboardParser.
+type BillboardParser interface {
+	Parse(htmlContent string) ([]BillboardEntry, error)
+}
+
+// defaultBillboardParser is the parser fetchBillboardChart uses.
+var defaultBillboardParser BillboardParser = &domBillboardParser{}
+
+// SetBillboardParser replaces the parser used by FetchBillboardHot100 and
+// the rest of the Billboard-family chart providers.
+func SetBillboardParser(p BillboardParser) {
+	defaultBillboardParser = p
+}
+
+// domBillboardParser parses Billboard's chart markup by traversing the DOM
+// and selecting elements by tag/class/id, the same way billboard.com's own
+// stylesheet targets them.
+type domBillboardParser struct{}
+
+func (domBillboardParser) Parse(htmlContent string) ([]BillboardEntry, error) {
+	doc, err := xhtml.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	rows := findAllNodes(doc, func(n *xhtml.Node) bool {
+		return n.Type == xhtml.ElementNode && n.Data == "ul" && hasClass(n, "o-chart-results-list-row")
+	})
+
+	entries := make([]BillboardEntry, 0, len(rows))
+	for i, row := range rows {
+		entry := parseBillboardRow(row)
+		if entry.Title == "" || entry.Artist == "" {
+			continue
+		}
+		if entry.Rank == 0 {
+			entry.Rank = i + 1
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseBillboardRow(row *xhtml.Node) BillboardEntry {
+	var entry BillboardEntry
+
+	order := documentOrder(row)
+
+	titleNode := findFirstNode(row, func(n *xhtml.Node) bool {
+		return n.Type == xhtml.ElementNode && n.Data == "h3" && attr(n, "id") == "title-of-a-story"
+	})
+	titleIdx := -1
+	if titleNode != nil {
+		entry.Title = nodeText(titleNode)
+		titleIdx = order[titleNode]
+	}
+
+	// The rank and the artist name are both rendered as plain c-label
+	// spans; the rank is the first purely numeric one (it comes before
+	// the title), and the artist is the first non-numeric one that comes
+	// after the title.
+	labelSpans := findAllNodes(row, func(n *xhtml.Node) bool {
+		return n.Type == xhtml.ElementNode && n.Data == "span" && hasClass(n, "c-label")
+	})
+	for _, span := range labelSpans {
+		text := nodeText(span)
+		if text == "" {
+			continue
+		}
+		idx := order[span]
+
+		if rank, err := strconv.Atoi(text); err == nil {
+			if entry.Rank == 0 && (titleIdx == -1 || idx < titleIdx) {
+				entry.Rank = rank
+			}
+			continue
+		}
+
+		if entry.Artist == "" && titleIdx >= 0 && idx > titleIdx {
+			entry.Artist = text
+		}
+	}
+
+	if entry.Artist == "" {
+		if link := findFirstNode(row, func(n *xhtml.Node) bool {
+			return n.Type == xhtml.ElementNode && n.Data == "a" && strings.HasPrefix(attr(n, "href"), "/artist/")
+		}); link != nil {
+			entry.Artist = nodeText(link)
+		}
+	}
+
+	for _, li := range findAllNodes(row, func(n *xhtml.Node) bool { return n.Type == xhtml.ElementNode && n.Data == "li" }) {
+		spans := findAllNodes(li, func(n *xhtml.Node) bool {
+			return n.Type == xhtml.ElementNode && n.Data == "span" && hasClass(n, "c-label")
+		})
+		if len(spans) < 2 {
+			continue
+		}
+
+		label := strings.ToLower(nodeText(spans[0]))
+		value, err := strconv.Atoi(nodeText(spans[1]))
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case strings.Contains(label, "last week"):
+			entry.LastWeekRank = value
+		case strings.Contains(label, "peak"):
+			entry.PeakRank = value
+		case strings.Contains(label, "wks on chart"), strings.Contains(label, "weeks on chart"):
+			entry.WeeksOnChart = value
+		}
+	}
+
+	// Match the badge element's own text exactly rather than scanning the
+	// whole row, so an artist/title that merely contains "new" (e.g. "The
+	// New Pornographers") doesn't get misread as a NEW badge. Re-Entry
+	// badges wrap across a line break ("RE-\nENTRY"), so compare with
+	// hyphens/spaces stripped.
+	badge := findFirstNode(row, func(n *xhtml.Node) bool {
+		if n.Type != xhtml.ElementNode || n == titleNode {
+			return false
+		}
+		switch normalizeBadgeText(nodeText(n)) {
+		case "NEW", "REENTRY":
+			return true
+		}
+		return false
+	})
+	if badge != nil {
+		switch normalizeBadgeText(nodeText(badge)) {
+		case "NEW":
+			entry.IsNew = true
+		case "REENTRY":
+			entry.IsReturning = true
+		}
+	}
+
+	return entry
+}
+
+func normalizeBadgeText(text string) string {
+	text = strings.ToUpper(text)
+	text = strings.ReplaceAll(text, "-", "")
+	text = strings.ReplaceAll(text, " ", "")
+	return text
+}
+
+// findAllNodes returns every descendant of n (including n itself) matching
+// match, in document order.
+func findAllNodes(n *xhtml.Node, match func(*xhtml.Node) bool) []*xhtml.Node {
+	var out []*xhtml.Node
+	var walk func(*xhtml.Node)
+	walk = func(cur *xhtml.Node) {
+		if match(cur) {
+			out = append(out, cur)
+		}
+		for c := cur.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return out
+}
+
+// findFirstNode returns the first descendant of n (including n itself)
+// matching match, or nil.
+func findFirstNode(n *xhtml.Node, match func(*xhtml.Node) bool) *xhtml.Node {
+	if match(n) {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findFirstNode(c, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func hasClass(n *xhtml.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *xhtml.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func nodeText(n *xhtml.Node) string {
+	var buf strings.Builder
+	var walk func(*xhtml.Node)
+	walk = func(cur *xhtml.Node) {
+		if cur.Type == xhtml.TextNode {
+			buf.WriteString(cur.Data)
+		}
+		for c := cur.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// documentOrder returns a map from every node under root (inclusive) to its
+// preorder index, so two nodes' relative positions can be compared with a
+// simple integer comparison.
+func documentOrder(root *xhtml.Node) map[*xhtml.Node]int {
+	order := make(map[*xhtml.Node]int)
+	var walk func(*xhtml.Node)
+	walk = func(n *xhtml.Node) {
+		order[n] = len(order)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return order
+}
@@ -0,0 +1,161 @@
+package backend
+
+import "testing"
+
+// These fixtures are simplified snapshots of billboard.com's chart row
+// markup from different weeks: a fresh entry (NEW badge), a re-entry
+// (RE-ENTRY badge, split across a line break the way Billboard renders
+// it), and a week where the row leads with a <video> element instead of
+// an <img> before the title. The DOM parser selects by tag/id/class, not
+// position, so all three must parse identically regardless of what
+// precedes the title.
+const billboardNewEntryFixture = `
+<html><body><div class="chart-results">
+<ul class="o-chart-results-list-row  lrv-u-flex">
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label  a-font-primary-bold-l">42</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <img class="c-lazy-image__img" src="cover.jpg">
+    <h3 class="c-title  a-font-primary-bold-s" id="title-of-a-story">Midnight Static</h3>
+    <span class="c-label  a-no-trucate">Nova Tide</span>
+    <span class="c-label  a-badge-chicklet">NEW</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Last Week</span>
+    <span class="c-label">-</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Peak Pos.</span>
+    <span class="c-label">42</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Wks on Chart</span>
+    <span class="c-label">1</span>
+  </li>
+</ul>
+</div></body></html>
+`
+
+const billboardReEntryFixture = `
+<html><body><div class="chart-results">
+<ul class="o-chart-results-list-row  lrv-u-flex">
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label  a-font-primary-bold-l">17</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <img class="c-lazy-image__img" src="cover.jpg">
+    <h3 class="c-title  a-font-primary-bold-s" id="title-of-a-story">Old Flame</h3>
+    <span class="c-label  a-no-trucate">Harbor Lights</span>
+    <span class="c-label  a-badge-chicklet">RE-<br/>ENTRY</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Last Week</span>
+    <span class="c-label">-</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Peak Pos.</span>
+    <span class="c-label">9</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Wks on Chart</span>
+    <span class="c-label">15</span>
+  </li>
+</ul>
+</div></body></html>
+`
+
+const billboardVideoFirstFixture = `
+<html><body><div class="chart-results">
+<ul class="o-chart-results-list-row  lrv-u-flex">
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label  a-font-primary-bold-l">3</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <video class="c-lazy-image__video" src="teaser.mp4"></video>
+    <h3 class="c-title  a-font-primary-bold-s" id="title-of-a-story">Gold Rush</h3>
+    <span class="c-label  a-no-trucate">The New Pornographers</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Last Week</span>
+    <span class="c-label">4</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Peak Pos.</span>
+    <span class="c-label">2</span>
+  </li>
+  <li class="o-chart-results-list__item  u-width-100">
+    <span class="c-label">Wks on Chart</span>
+    <span class="c-label">20</span>
+  </li>
+</ul>
+</div></body></html>
+`
+
+func TestDOMBillboardParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		html    string
+		want    BillboardEntry
+	}{
+		{
+			name: "new entry, image-first layout",
+			html: billboardNewEntryFixture,
+			want: BillboardEntry{
+				Rank:         42,
+				Title:        "Midnight Static",
+				Artist:       "Nova Tide",
+				LastWeekRank: 0,
+				PeakRank:     42,
+				WeeksOnChart: 1,
+				IsNew:        true,
+				IsReturning:  false,
+			},
+		},
+		{
+			name: "re-entry, badge split across a line break",
+			html: billboardReEntryFixture,
+			want: BillboardEntry{
+				Rank:         17,
+				Title:        "Old Flame",
+				Artist:       "Harbor Lights",
+				LastWeekRank: 0,
+				PeakRank:     9,
+				WeeksOnChart: 15,
+				IsNew:        false,
+				IsReturning:  true,
+			},
+		},
+		{
+			name: "carried-over entry, video-first layout, artist text contains \"New\"",
+			html: billboardVideoFirstFixture,
+			want: BillboardEntry{
+				Rank:         3,
+				Title:        "Gold Rush",
+				Artist:       "The New Pornographers",
+				LastWeekRank: 4,
+				PeakRank:     2,
+				WeeksOnChart: 20,
+				IsNew:        false,
+				IsReturning:  false,
+			},
+		},
+	}
+
+	parser := domBillboardParser{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parser.Parse(tt.html)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("Parse() got %d entries, want 1", len(entries))
+			}
+			if got := entries[0]; got != tt.want {
+				t.Errorf("Parse() entry = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package backend
+
+import "context"
+
+// billboardProvider adapts one Billboard chart (identified by its URL slug)
+// to the ChartProvider interface.
+type billboardProvider struct {
+	meta ChartMeta
+	slug string
+}
+
+func newBillboardHot100Provider() *billboardProvider {
+	return &billboardProvider{
+		slug: "hot-100",
+		meta: ChartMeta{
+			ID:                "billboard-hot-100",
+			Name:              "Billboard Hot 100",
+			Frequency:         "weekly",
+			SupportsDateRange: true,
+			Kind:              ChartKindSingles,
+		},
+	}
+}
+
+func newBillboard200Provider() *billboardProvider {
+	return &billboardProvider{
+		slug: "billboard-200",
+		meta: ChartMeta{
+			ID:                "billboard-200",
+			Name:              "Billboard 200",
+			Frequency:         "weekly",
+			SupportsDateRange: true,
+			Kind:              ChartKindAlbums,
+		},
+	}
+}
+
+func newBillboardGlobal200Provider() *billboardProvider {
+	return &billboardProvider{
+		slug: "billboard-global-200",
+		meta: ChartMeta{
+			ID:                "billboard-global-200",
+			Name:              "Billboard Global 200",
+			Frequency:         "weekly",
+			SupportsDateRange: true,
+			Kind:              ChartKindSingles,
+		},
+	}
+}
+
+func (p *billboardProvider) Meta() ChartMeta { return p.meta }
+
+func (p *billboardProvider) Fetch(ctx context.Context, params ChartFetchParams) (*Chart, error) {
+	date := params.Date
+	if date == "" {
+		date = GetCurrentBillboardDate()
+	}
+
+	bc, err := fetchBillboardChart(ctx, p.slug, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chart{
+		ProviderID: p.meta.ID,
+		Date:       bc.Date,
+		Kind:       p.meta.Kind,
+		Entries:    billboardEntriesToChartEntries(bc.Entries, params.Limit, p.meta.Kind),
+	}, nil
+}
+
+func billboardEntriesToChartEntries(entries []BillboardEntry, limit int, kind ChartKind) []ChartEntry {
+	if limit > 0 && limit < len(entries) {
+		entries = entries[:limit]
+	}
+
+	out := make([]ChartEntry, len(entries))
+	for i, e := range entries {
+		ce := ChartEntry{
+			Rank:         e.Rank,
+			Title:        e.Title,
+			Artist:       e.Artist,
+			LastWeekRank: e.LastWeekRank,
+			PeakRank:     e.PeakRank,
+			WeeksOnChart: e.WeeksOnChart,
+			IsNew:        e.IsNew,
+		}
+		// On album charts (e.g. Billboard 200), the "title" Billboard
+		// renders for each entry is the album's name, not a track's -
+		// surface it as Album too so ChartEntrySearchQuery and
+		// EnqueueChartDownload can tell the difference.
+		if kind == ChartKindAlbums {
+			ce.Album = e.Title
+		}
+		out[i] = ce
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ChartKind distinguishes the kind of items a chart ranks.
+type ChartKind string
+
+const (
+	ChartKindSingles ChartKind = "singles"
+	ChartKindAlbums  ChartKind = "albums"
+)
+
+// ChartEntry is a single normalized ranking on any chart, regardless of which
+// provider produced it.
+type ChartEntry struct {
+	Rank         int    `json:"rank"`
+	Title        string `json:"title"`
+	Artist       string `json:"artist"`
+	Album        string `json:"album,omitempty"`
+	LastWeekRank int    `json:"last_week_rank"`
+	PeakRank     int    `json:"peak_rank"`
+	WeeksOnChart int    `json:"weeks_on_chart"`
+	IsNew        bool   `json:"is_new"`
+}
+
+// Chart is the normalized result of fetching any chart through a
+// ChartProvider.
+type Chart struct {
+	ProviderID string       `json:"provider_id"`
+	Date       string       `json:"date"`
+	Kind       ChartKind    `json:"kind"`
+	Entries    []ChartEntry `json:"entries"`
+}
+
+// ChartMeta describes a chart a ChartProvider can fetch, for listing in a
+// ChartRegistry without needing to fetch anything.
+type ChartMeta struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Frequency         string    `json:"frequency"` // e.g. "weekly"
+	SupportsDateRange bool      `json:"supports_date_range"`
+	Kind              ChartKind `json:"kind"`
+}
+
+// ChartFetchParams carries the inputs a ChartProvider needs to fetch one
+// chart snapshot. Not every field is meaningful to every provider: Date is
+// ignored by providers that only expose the current chart, and Country is
+// ignored by providers that are not country-scoped.
+type ChartFetchParams struct {
+	// Date is the chart date in YYYY-MM-DD form. Empty means "most recent".
+	Date string
+	// Country is an ISO 3166-1 alpha-2 country code for country-scoped
+	// charts (e.g. Spotify's and Apple Music's per-country top lists).
+	Country string
+	// Limit caps the number of entries returned. Zero means "provider
+	// default", typically the full chart.
+	Limit int
+}
+
+// ChartProvider is implemented by anything that can fetch a normalized
+// Chart for one chart source (Billboard Hot 100, Spotify's per-country top
+// 50s, etc).
+type ChartProvider interface {
+	Meta() ChartMeta
+	Fetch(ctx context.Context, params ChartFetchParams) (*Chart, error)
+}
+
+// ChartRegistry tracks the set of available ChartProviders and mediates
+// fetches through per-provider rate limiting and response caching so
+// callers don't need to worry about hammering a provider's HTML endpoint.
+type ChartRegistry struct {
+	mu        sync.RWMutex
+	providers map[string]ChartProvider
+	limiters  map[string]*rateLimiter
+	cache     *chartCache
+}
+
+// NewChartRegistry returns an empty registry. Use Register to add providers,
+// or DefaultChartRegistry for the built-in set.
+func NewChartRegistry() *ChartRegistry {
+	return &ChartRegistry{
+		providers: make(map[string]ChartProvider),
+		limiters:  make(map[string]*rateLimiter),
+		cache:     newChartCache(15 * time.Minute),
+	}
+}
+
+// Register adds a provider to the registry, keyed by its Meta().ID. A
+// provider registered under an ID that already exists replaces the
+// previous one.
+func (r *ChartRegistry) Register(p ChartProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := p.Meta().ID
+	r.providers[id] = p
+	if _, ok := r.limiters[id]; !ok {
+		// One request per second per provider is a conservative default
+		// that keeps us well clear of being rate limited or blocked.
+		r.limiters[id] = newRateLimiter(time.Second)
+	}
+}
+
+// List returns metadata for every registered chart, sorted by ID.
+func (r *ChartRegistry) List() []ChartMeta {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	metas := make([]ChartMeta, 0, len(r.providers))
+	for _, p := range r.providers {
+		metas = append(metas, p.Meta())
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].ID < metas[j].ID })
+	return metas
+}
+
+// Get returns the provider registered under id, if any.
+func (r *ChartRegistry) Get(id string) (ChartProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[id]
+	return p, ok
+}
+
+// FetchChart fetches the chart identified by providerID, serving a cached
+// response when one is fresh and otherwise rate limiting the underlying
+// request to the provider.
+func (r *ChartRegistry) FetchChart(ctx context.Context, providerID string, params ChartFetchParams) (*Chart, error) {
+	r.mu.RLock()
+	provider, ok := r.providers[providerID]
+	limiter := r.limiters[providerID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown chart provider %q", providerID)
+	}
+
+	cacheKey := chartCacheKey(providerID, params)
+	if cached, ok := r.cache.get(cacheKey); ok {
+		return cached, nil
+	}
+
+	if limiter != nil {
+		if err := limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	chart, err := provider.Fetch(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch chart %q: %w", providerID, err)
+	}
+
+	r.cache.set(cacheKey, chart)
+	return chart, nil
+}
+
+func chartCacheKey(providerID string, params ChartFetchParams) string {
+	return fmt.Sprintf("%s|%s|%s|%d", providerID, params.Date, params.Country, params.Limit)
+}
+
+// DefaultChartRegistry is pre-populated with every chart provider this
+// package ships: the Billboard family, Spotify's per-country Top 50s, Apple
+// Music's per-country Top 100s, and Last.fm's weekly top tracks.
+var DefaultChartRegistry = newDefaultChartRegistry()
+
+func newDefaultChartRegistry() *ChartRegistry {
+	r := NewChartRegistry()
+	r.Register(newBillboardHot100Provider())
+	r.Register(newBillboard200Provider())
+	r.Register(newBillboardGlobal200Provider())
+	r.Register(newSpotifyChartsProvider())
+	r.Register(newAppleMusicProvider())
+	r.Register(newLastFMProvider())
+	return r
+}
+
+// ChartEntrySearchQuery resolves a chart entry into a search query for the
+// existing Spotify search backend, so a fetched chart can be queued as a
+// playlist-like download job one entry at a time. kind must match the
+// Chart the entry came from: on an album chart (ChartKindAlbums), Title
+// holds the album's name rather than a track's, so the query searches for
+// the album instead of misreading it as a song title.
+func ChartEntrySearchQuery(e ChartEntry, kind ChartKind) string {
+	if kind == ChartKindAlbums {
+		album := e.Album
+		if album == "" {
+			album = e.Title
+		}
+		return fmt.Sprintf("album:%s artist:%s", album, e.Artist)
+	}
+
+	if e.Album != "" {
+		return fmt.Sprintf("track:%s artist:%s album:%s", e.Title, e.Artist, e.Album)
+	}
+	return fmt.Sprintf("track:%s artist:%s", e.Title, e.Artist)
+}
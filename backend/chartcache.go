@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// chartCache is a small TTL cache for fetched charts, keyed by provider and
+// fetch params, so repeated lookups for the same chart+date don't re-hit the
+// provider's HTTP endpoint.
+type chartCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]chartCacheEntry
+}
+
+type chartCacheEntry struct {
+	chart   *Chart
+	expires time.Time
+}
+
+func newChartCache(ttl time.Duration) *chartCache {
+	return &chartCache{
+		ttl:     ttl,
+		entries: make(map[string]chartCacheEntry),
+	}
+}
+
+func (c *chartCache) get(key string) (*Chart, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.chart, true
+}
+
+func (c *chartCache) set(key string, chart *Chart) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = chartCacheEntry{chart: chart, expires: time.Now().Add(c.ttl)}
+}
+
+// rateLimiter enforces a minimum interval between requests to a single
+// provider so FetchChart can't accidentally hammer a chart source.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{interval: interval}
+}
+
+// wait blocks until the limiter's interval has elapsed since the last
+// request, or ctx is cancelled.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	elapsed := now.Sub(l.last)
+	var sleep time.Duration
+	if l.last.IsZero() || elapsed >= l.interval {
+		sleep = 0
+	} else {
+		sleep = l.interval - elapsed
+	}
+	l.last = now.Add(sleep)
+	l.mu.Unlock()
+
+	if sleep <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(sleep)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,414 @@
+package backend
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// TrackCandidate is one Spotify search result considered as a match for a
+// chart entry.
+type TrackCandidate struct {
+	ID         string
+	Title      string
+	Artist     string
+	Album      string
+	DurationMs int
+	ISRC       string
+}
+
+// TrackResolver searches the Spotify catalogue for a query built from a
+// chart entry. It is satisfied by the existing Spotify search backend;
+// it's expressed as an interface here so this package stays testable
+// without a live network dependency.
+type TrackResolver interface {
+	SearchTrack(ctx context.Context, query string) ([]TrackCandidate, error)
+}
+
+// ISRCIndex tracks which ISRCs have already been downloaded, so
+// EnqueueChartDownload can skip re-queuing tracks a user already has.
+// NewInMemoryISRCIndex is lost on restart; NewSQLiteISRCIndex is the
+// persistent default for anything longer-lived than one process run.
+type ISRCIndex interface {
+	Has(isrc string) bool
+	Add(isrc string)
+}
+
+type memoryISRCIndex struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// NewInMemoryISRCIndex returns an ISRCIndex backed by an in-memory set.
+func NewInMemoryISRCIndex() ISRCIndex {
+	return &memoryISRCIndex{seen: make(map[string]bool)}
+}
+
+func (idx *memoryISRCIndex) Has(isrc string) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.seen[isrc]
+}
+
+func (idx *memoryISRCIndex) Add(isrc string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.seen[isrc] = true
+}
+
+// sqliteISRCIndex persists seen ISRCs to a SQLite database, so
+// SkipDownloaded keeps working across restarts instead of forgetting
+// every ISRC memoryISRCIndex had seen.
+type sqliteISRCIndex struct {
+	db *sql.DB
+}
+
+// NewSQLiteISRCIndex opens (creating if necessary) a SQLite-backed
+// ISRCIndex at path.
+func NewSQLiteISRCIndex(path string) (ISRCIndex, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ISRC index database: %w", err)
+	}
+
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS seen_isrcs (isrc TEXT PRIMARY KEY)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create ISRC index schema: %w", err)
+	}
+
+	return &sqliteISRCIndex{db: db}, nil
+}
+
+func (idx *sqliteISRCIndex) Has(isrc string) bool {
+	var exists int
+	err := idx.db.QueryRow(`SELECT 1 FROM seen_isrcs WHERE isrc = ?`, isrc).Scan(&exists)
+	return err == nil
+}
+
+func (idx *sqliteISRCIndex) Add(isrc string) {
+	// Best-effort: ISRCIndex.Add has no error return, and a failed insert
+	// just means this one track may be re-queued on a later run.
+	idx.db.Exec(`INSERT OR IGNORE INTO seen_isrcs (isrc) VALUES (?)`, isrc)
+}
+
+// ChartDownloadOpts configures EnqueueChartDownload.
+type ChartDownloadOpts struct {
+	// TopN limits the job to the first N chart entries. Zero means every
+	// entry in the chart.
+	TopN int
+	// MinConfidence is the minimum match-confidence (0-1) a resolved
+	// track needs to be queued. Zero uses a 0.6 default.
+	MinConfidence float64
+	// SkipDownloaded, when true, skips entries whose matched ISRC is
+	// already present in Dedupe.
+	SkipDownloaded bool
+	// Dedupe is consulted when SkipDownloaded is set. Whenever Dedupe is
+	// non-nil, every queued track's ISRC is added to it, whether or not
+	// SkipDownloaded is set.
+	Dedupe ISRCIndex
+	// FolderFormat and FilenameFormat are passed to BuildExpectedPath to
+	// populate each ResolvedTrack's ExpectedPath, so e.g. an album chart
+	// can be organized differently from a singles chart. Empty strings
+	// fall back to BuildExpectedFilename's defaults with no subfolder.
+	FolderFormat   string
+	FilenameFormat string
+}
+
+const defaultMatchConfidence = 0.6
+
+// ResolvedTrack pairs a chart entry with the Spotify track it was matched
+// to and the confidence of that match.
+type ResolvedTrack struct {
+	Entry      ChartEntry
+	Candidate  TrackCandidate
+	Confidence float64
+	// ExpectedPath is where this track should be written, built from
+	// ChartDownloadOpts.FolderFormat/FilenameFormat via BuildExpectedPath.
+	// If neither format was set, it is BuildExpectedFilename's default
+	// filename with no folder.
+	ExpectedPath string
+}
+
+// UnresolvedEntry is a chart entry EnqueueChartDownload could not confidently
+// match to a Spotify track.
+type UnresolvedEntry struct {
+	Entry  ChartEntry
+	Reason string
+}
+
+// Job is a synthetic playlist-like download job built from a fetched
+// chart: one track per resolved entry, named so PlaylistName/PlaylistOwner
+// populate {playlist}/{creator} in BuildExpectedPath.
+type Job struct {
+	PlaylistName  string
+	PlaylistOwner string
+	Tracks        []ResolvedTrack
+	Unresolved    []UnresolvedEntry
+}
+
+// EnqueueChartDownload resolves every entry in chart to a Spotify track via
+// resolver and returns a Job the existing download pipeline can consume as
+// a playlist. Entries that can't be matched with at least MinConfidence are
+// reported in Job.Unresolved rather than causing the whole fetch to fail.
+func EnqueueChartDownload(ctx context.Context, chart *Chart, resolver TrackResolver, opts ChartDownloadOpts) (*Job, error) {
+	if chart == nil {
+		return nil, fmt.Errorf("chart is nil")
+	}
+
+	minConfidence := opts.MinConfidence
+	if minConfidence <= 0 {
+		minConfidence = defaultMatchConfidence
+	}
+
+	if opts.FolderFormat != "" {
+		if _, err := ParseTemplate(opts.FolderFormat); err != nil {
+			return nil, fmt.Errorf("invalid folder format: %w", err)
+		}
+	}
+	if opts.FilenameFormat != "" {
+		if _, err := ParseTemplate(opts.FilenameFormat); err != nil {
+			return nil, fmt.Errorf("invalid filename format: %w", err)
+		}
+	}
+
+	entries := chart.Entries
+	if opts.TopN > 0 && opts.TopN < len(entries) {
+		entries = entries[:opts.TopN]
+	}
+
+	job := &Job{
+		PlaylistName:  fmt.Sprintf("%s — %s", chartDisplayName(chart.ProviderID), chart.Date),
+		PlaylistOwner: "Chart Sync",
+	}
+
+	for _, entry := range entries {
+		candidates, err := resolver.SearchTrack(ctx, ChartEntrySearchQuery(entry, chart.Kind))
+		if err != nil {
+			job.Unresolved = append(job.Unresolved, UnresolvedEntry{Entry: entry, Reason: err.Error()})
+			continue
+		}
+
+		best, confidence := bestTrackMatch(entry, candidates, chart.Kind)
+		if best == nil || confidence < minConfidence {
+			job.Unresolved = append(job.Unresolved, UnresolvedEntry{
+				Entry:  entry,
+				Reason: fmt.Sprintf("no match with confidence >= %.2f", minConfidence),
+			})
+			continue
+		}
+
+		if opts.SkipDownloaded && opts.Dedupe != nil && best.ISRC != "" && opts.Dedupe.Has(best.ISRC) {
+			continue
+		}
+
+		expectedPath, err := BuildExpectedPath(opts.FolderFormat, opts.FilenameFormat, best.Title, best.Artist, best.Album, "", "", job.PlaylistName, job.PlaylistOwner, false, entry.Rank, 0, false)
+		if err != nil {
+			job.Unresolved = append(job.Unresolved, UnresolvedEntry{Entry: entry, Reason: err.Error()})
+			continue
+		}
+
+		job.Tracks = append(job.Tracks, ResolvedTrack{Entry: entry, Candidate: *best, Confidence: confidence, ExpectedPath: expectedPath})
+		if opts.Dedupe != nil && best.ISRC != "" {
+			opts.Dedupe.Add(best.ISRC)
+		}
+	}
+
+	return job, nil
+}
+
+func chartDisplayName(providerID string) string {
+	if provider, ok := DefaultChartRegistry.Get(providerID); ok {
+		return provider.Meta().Name
+	}
+	return providerID
+}
+
+// bestTrackMatch scores every candidate against entry and returns the
+// highest-scoring one along with its confidence, or nil if candidates is
+// empty. kind must match the Chart entry came from: on an album chart
+// (ChartKindAlbums), candidates are scored against entry's album rather
+// than its (Billboard-reported-as-track) title.
+func bestTrackMatch(entry ChartEntry, candidates []TrackCandidate, kind ChartKind) (*TrackCandidate, float64) {
+	// Candidates sharing near-identical title/artist text (a remix, a
+	// live version, a different song entirely that happens to share
+	// title words) are common false positives. Most search results for
+	// the right track cluster around its actual duration, so candidates
+	// far from that cluster's center are the more likely mismatches.
+	typicalDurationMs := medianCandidateDuration(candidates)
+
+	var best *TrackCandidate
+	var bestScore float64
+
+	for i, candidate := range candidates {
+		score := matchConfidence(entry, candidate, kind, typicalDurationMs)
+		if best == nil || score > bestScore {
+			best = &candidates[i]
+			bestScore = score
+		}
+	}
+
+	return best, bestScore
+}
+
+// matchConfidence scores how likely candidate is the chart entry's track,
+// combining normalized title similarity, artist token overlap, and (when
+// duration data is available) a sanity check against typicalDurationMs.
+func matchConfidence(entry ChartEntry, candidate TrackCandidate, kind ChartKind, typicalDurationMs int) float64 {
+	entryTitle := entry.Title
+	candidateTitle := candidate.Title
+	if kind == ChartKindAlbums {
+		if entry.Album != "" {
+			entryTitle = entry.Album
+		}
+		candidateTitle = candidate.Album
+	}
+
+	titleScore := levenshteinSimilarity(normalizeForMatch(entryTitle), normalizeForMatch(candidateTitle))
+	artistScore := tokenOverlap(normalizeForMatch(entry.Artist), normalizeForMatch(candidate.Artist))
+	durationScore := durationSanityScore(candidate.DurationMs, typicalDurationMs)
+	return 0.5*titleScore + 0.3*artistScore + 0.2*durationScore
+}
+
+// medianCandidateDuration returns the median DurationMs across candidates
+// that reported one, or 0 if none did.
+func medianCandidateDuration(candidates []TrackCandidate) int {
+	var durations []int
+	for _, c := range candidates {
+		if c.DurationMs > 0 {
+			durations = append(durations, c.DurationMs)
+		}
+	}
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sort.Ints(durations)
+	return durations[len(durations)/2]
+}
+
+// durationSanityScore returns 1 when duration data isn't available for
+// either side, and otherwise scores candidateMs down the further it drifts
+// from typicalMs, reaching 0 once the drift equals typicalMs itself.
+func durationSanityScore(candidateMs, typicalMs int) float64 {
+	if candidateMs <= 0 || typicalMs <= 0 {
+		return 1
+	}
+
+	const toleranceMs = 10_000 // studio vs. radio edits routinely differ by a few seconds
+	diff := candidateMs - typicalMs
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff <= toleranceMs {
+		return 1
+	}
+
+	score := 1 - float64(diff)/float64(typicalMs)
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+var matchPunctuation = regexp.MustCompile(`[^a-z0-9 ]+`)
+
+func normalizeForMatch(s string) string {
+	s = strings.ToLower(s)
+	s = matchPunctuation.ReplaceAllString(s, "")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// levenshteinSimilarity returns 1 for identical strings and scales down to
+// 0 as the edit distance approaches the length of the longer string.
+func levenshteinSimilarity(a, b string) float64 {
+	if a == "" && b == "" {
+		return 1
+	}
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance(a, b))/float64(maxLen)
+}
+
+func levenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// tokenOverlap returns the Jaccard similarity of a's and b's word tokens.
+func tokenOverlap(a, b string) float64 {
+	aTokens := strings.Fields(a)
+	bTokens := strings.Fields(b)
+	if len(aTokens) == 0 && len(bTokens) == 0 {
+		return 1
+	}
+
+	bSet := make(map[string]bool, len(bTokens))
+	for _, t := range bTokens {
+		bSet[t] = true
+	}
+
+	aSet := make(map[string]bool, len(aTokens))
+	intersection := 0
+	for _, t := range aTokens {
+		if aSet[t] {
+			continue
+		}
+		aSet[t] = true
+		if bSet[t] {
+			intersection++
+		}
+	}
+
+	union := len(aSet)
+	for t := range bSet {
+		if !aSet[t] {
+			union++
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+
+	return float64(intersection) / float64(union)
+}
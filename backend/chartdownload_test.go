@@ -0,0 +1,122 @@
+package backend
+
+import "testing"
+
+func TestLevenshteinSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "identical strings", a: "gold rush", b: "gold rush", want: 1},
+		{name: "both empty", a: "", b: "", want: 1},
+		{name: "completely different", a: "abc", b: "xyz", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := levenshteinSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshteinSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "identical token sets", a: "nova tide", b: "nova tide", want: 1},
+		{name: "no overlap", a: "nova tide", b: "harbor lights", want: 0},
+		{name: "partial overlap", a: "nova tide feat runner", b: "nova tide", want: 2.0 / 4.0},
+		{name: "both empty", a: "", b: "", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenOverlap(tt.a, tt.b); got != tt.want {
+				t.Errorf("tokenOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationSanityScore(t *testing.T) {
+	tests := []struct {
+		name                 string
+		candidateMs, typical int
+		want                 float64
+	}{
+		{name: "no candidate duration", candidateMs: 0, typical: 200_000, want: 1},
+		{name: "no typical duration", candidateMs: 200_000, typical: 0, want: 1},
+		{name: "within tolerance", candidateMs: 200_000, typical: 205_000, want: 1},
+		{name: "far from typical scores low", candidateMs: 400_000, typical: 200_000, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := durationSanityScore(tt.candidateMs, tt.typical); got != tt.want {
+				t.Errorf("durationSanityScore(%d, %d) = %v, want %v", tt.candidateMs, tt.typical, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchConfidencePrefersCloserMatch(t *testing.T) {
+	entry := ChartEntry{Title: "Gold Rush", Artist: "Nova Tide"}
+
+	exact := TrackCandidate{Title: "Gold Rush", Artist: "Nova Tide", DurationMs: 200_000}
+	remix := TrackCandidate{Title: "Gold Rush (Remix)", Artist: "Nova Tide", DurationMs: 350_000}
+
+	exactScore := matchConfidence(entry, exact, ChartKindSingles, 200_000)
+	remixScore := matchConfidence(entry, remix, ChartKindSingles, 200_000)
+
+	if exactScore <= remixScore {
+		t.Errorf("matchConfidence(exact) = %v, want greater than matchConfidence(remix) = %v", exactScore, remixScore)
+	}
+}
+
+func TestMatchConfidenceUsesAlbumFieldForAlbumCharts(t *testing.T) {
+	entry := ChartEntry{Title: "Nightfall", Album: "Nightfall", Artist: "Nova Tide"}
+	candidate := TrackCandidate{Title: "Intro", Album: "Nightfall", Artist: "Nova Tide", DurationMs: 200_000}
+
+	score := matchConfidence(entry, candidate, ChartKindAlbums, 0)
+	if score < 0.7 {
+		t.Errorf("matchConfidence() for matching album = %v, want >= 0.7", score)
+	}
+}
+
+func TestBestTrackMatch(t *testing.T) {
+	entry := ChartEntry{Title: "Gold Rush", Artist: "Nova Tide"}
+
+	candidates := []TrackCandidate{
+		{Title: "Gold Rush (Live)", Artist: "Nova Tide", DurationMs: 260_000, ISRC: "LIVE1"},
+		{Title: "Gold Rush", Artist: "Nova Tide", DurationMs: 200_000, ISRC: "STUDIO1"},
+		{Title: "Silver Rush", Artist: "Someone Else", DurationMs: 200_000, ISRC: "UNRELATED1"},
+	}
+
+	best, confidence := bestTrackMatch(entry, candidates, ChartKindSingles)
+	if best == nil {
+		t.Fatal("bestTrackMatch() returned nil candidate")
+	}
+	if best.ISRC != "STUDIO1" {
+		t.Errorf("bestTrackMatch() chose ISRC %q, want %q", best.ISRC, "STUDIO1")
+	}
+	if confidence <= 0 {
+		t.Errorf("bestTrackMatch() confidence = %v, want > 0", confidence)
+	}
+}
+
+func TestBestTrackMatchNoCandidates(t *testing.T) {
+	entry := ChartEntry{Title: "Gold Rush", Artist: "Nova Tide"}
+
+	best, confidence := bestTrackMatch(entry, nil, ChartKindSingles)
+	if best != nil {
+		t.Errorf("bestTrackMatch() with no candidates = %+v, want nil", best)
+	}
+	if confidence != 0 {
+		t.Errorf("bestTrackMatch() confidence with no candidates = %v, want 0", confidence)
+	}
+}
@@ -13,60 +13,68 @@ func BuildExpectedFilename(trackName, artistName, albumName, albumArtist, releas
 
 	safeTitle := SanitizeFilename(trackName)
 	safeArtist := SanitizeFilename(artistName)
-	safeAlbum := SanitizeFilename(albumName)
-	safeAlbumArtist := SanitizeFilename(albumArtist)
 
-	safePlaylist := SanitizeFilename(playlistName)
-	safeCreator := SanitizeFilename(playlistOwner)
-
-	year := ""
-	if len(releaseDate) >= 4 {
-		year = releaseDate[:4]
+	if strings.Contains(filenameFormat, "{") {
+		fields := trackNameFields(trackName, artistName, albumName, albumArtist, releaseDate, playlistName, playlistOwner, position, discNumber)
+		filename, err := RenderFilename(filenameFormat, fields)
+		if err != nil {
+			// Fall back to the plain title/artist layout rather than
+			// surfacing a parse error to a caller that only expects a
+			// filename string back.
+			return fmt.Sprintf("%s - %s.flac", safeTitle, safeArtist)
+		}
+		return filename
 	}
 
 	var filename string
+	switch filenameFormat {
+	case "artist-title":
+		filename = fmt.Sprintf("%s - %s", safeArtist, safeTitle)
+	case "title":
+		filename = safeTitle
+	default:
+		filename = fmt.Sprintf("%s - %s", safeTitle, safeArtist)
+	}
 
-	if strings.Contains(filenameFormat, "{") {
-		filename = filenameFormat
-		filename = strings.ReplaceAll(filename, "{title}", safeTitle)
-		filename = strings.ReplaceAll(filename, "{artist}", safeArtist)
-		filename = strings.ReplaceAll(filename, "{album}", safeAlbum)
-		filename = strings.ReplaceAll(filename, "{album_artist}", safeAlbumArtist)
-		filename = strings.ReplaceAll(filename, "{year}", year)
-		filename = strings.ReplaceAll(filename, "{playlist}", safePlaylist)
-		filename = strings.ReplaceAll(filename, "{creator}", safeCreator)
-
-		if discNumber > 0 {
-			filename = strings.ReplaceAll(filename, "{disc}", fmt.Sprintf("%d", discNumber))
-		} else {
-			filename = strings.ReplaceAll(filename, "{disc}", "")
-		}
+	if includeTrackNumber && position > 0 {
+		filename = fmt.Sprintf("%02d. %s", position, filename)
+	}
+
+	return filename + ".flac"
+}
 
-		if position > 0 {
-			filename = strings.ReplaceAll(filename, "{track}", fmt.Sprintf("%02d", position))
-		} else {
+func trackNameFields(trackName, artistName, albumName, albumArtist, releaseDate, playlistName, playlistOwner string, position, discNumber int) TrackFields {
+	return TrackFields{
+		Title:       trackName,
+		Artist:      artistName,
+		Album:       albumName,
+		AlbumArtist: albumArtist,
+		ReleaseDate: releaseDate,
+		Playlist:    playlistName,
+		Creator:     playlistOwner,
+		Track:       position,
+		Disc:        discNumber,
+	}
+}
 
-			filename = regexp.MustCompile(`\{track\}\.\s*`).ReplaceAllString(filename, "")
-			filename = regexp.MustCompile(`\{track\}\s*-\s*`).ReplaceAllString(filename, "")
-			filename = regexp.MustCompile(`\{track\}\s*`).ReplaceAllString(filename, "")
-		}
-	} else {
-
-		switch filenameFormat {
-		case "artist-title":
-			filename = fmt.Sprintf("%s - %s", safeArtist, safeTitle)
-		case "title":
-			filename = safeTitle
-		default:
-			filename = fmt.Sprintf("%s - %s", safeTitle, safeArtist)
-		}
+// BuildExpectedPath renders folderFormat and filenameFormat as separate
+// templates and joins them, so albums, singles, and playlists can be
+// organized into different directory layouts instead of sharing one flat
+// naming scheme.
+func BuildExpectedPath(folderFormat, filenameFormat, trackName, artistName, albumName, albumArtist, releaseDate, playlistName, playlistOwner string, includeTrackNumber bool, position, discNumber int, useAlbumTrackNumber bool) (string, error) {
+	filename := BuildExpectedFilename(trackName, artistName, albumName, albumArtist, releaseDate, filenameFormat, playlistName, playlistOwner, includeTrackNumber, position, discNumber, useAlbumTrackNumber)
 
-		if includeTrackNumber && position > 0 {
-			filename = fmt.Sprintf("%02d. %s", position, filename)
-		}
+	if !strings.Contains(folderFormat, "{") {
+		return filepath.Join(NormalizePath(SanitizeFolderPath(folderFormat)), filename), nil
 	}
 
-	return filename + ".flac"
+	fields := trackNameFields(trackName, artistName, albumName, albumArtist, releaseDate, playlistName, playlistOwner, position, discNumber)
+	folder, err := RenderFolderPath(folderFormat, fields)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(folder, filename), nil
 }
 
 func SanitizeFilename(name string) string {
@@ -0,0 +1,148 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// spotifyChartsProvider fetches Spotify's public "Top 50" regional charts,
+// published weekly per country via charts.spotify.com's JSON API.
+type spotifyChartsProvider struct{}
+
+func newSpotifyChartsProvider() *spotifyChartsProvider { return &spotifyChartsProvider{} }
+
+func (p *spotifyChartsProvider) Meta() ChartMeta {
+	return ChartMeta{
+		ID:                "spotify-charts",
+		Name:              "Spotify Charts (Top 50)",
+		Frequency:         "weekly",
+		SupportsDateRange: true,
+		Kind:              ChartKindSingles,
+	}
+}
+
+func (p *spotifyChartsProvider) Fetch(ctx context.Context, params ChartFetchParams) (*Chart, error) {
+	country := params.Country
+	if country == "" {
+		country = "global"
+	} else {
+		country = strings.ToLower(country)
+	}
+
+	date := params.Date
+	if date == "" {
+		date = GetCurrentBillboardDate()
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, fmt.Errorf("invalid date format, expected YYYY-MM-DD: %w", err)
+	}
+
+	url := fmt.Sprintf(spotifyChartsAPIURL, country, date)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Spotify chart: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Spotify charts returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	entries, err := parseSpotifyChartsJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Spotify chart: %w", err)
+	}
+
+	if params.Limit > 0 && params.Limit < len(entries) {
+		entries = entries[:params.Limit]
+	}
+
+	return &Chart{
+		ProviderID: p.Meta().ID,
+		Date:       date,
+		Kind:       ChartKindSingles,
+		Entries:    entries,
+	}, nil
+}
+
+// spotifyChartsAPIURL is Spotify's current regional-weekly charts endpoint.
+// spotifycharts.com (and its CSV download) was decommissioned in favor of
+// charts.spotify.com, which serves chart data as JSON instead of a CSV.
+const spotifyChartsAPIURL = "https://charts.spotify.com/api/v1/charts/regional-%s-weekly/%s"
+
+// spotifyChartsResponse is the subset of charts.spotify.com's
+// regional-weekly response this package reads.
+type spotifyChartsResponse struct {
+	ChartEntryViewResponses []struct {
+		Entries []struct {
+			ChartEntryData struct {
+				CurrentRank        int `json:"currentRank"`
+				PreviousRank       int `json:"previousRank"`
+				PeakRank           int `json:"peakRank"`
+				AppearancesOnChart int `json:"appearancesOnChart"`
+			} `json:"chartEntryData"`
+			TrackMetadata struct {
+				TrackName string `json:"trackName"`
+				Artists   []struct {
+					Name string `json:"name"`
+				} `json:"artists"`
+			} `json:"trackMetadata"`
+		} `json:"entries"`
+	} `json:"chartEntryViewResponses"`
+}
+
+// parseSpotifyChartsJSON parses the JSON body charts.spotify.com's
+// regional-weekly endpoint serves.
+func parseSpotifyChartsJSON(body []byte) ([]ChartEntry, error) {
+	var parsed spotifyChartsResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if len(parsed.ChartEntryViewResponses) == 0 {
+		return nil, fmt.Errorf("unexpected Spotify charts response layout")
+	}
+
+	rawEntries := parsed.ChartEntryViewResponses[0].Entries
+	entries := make([]ChartEntry, 0, len(rawEntries))
+	for _, e := range rawEntries {
+		if e.ChartEntryData.CurrentRank == 0 || e.TrackMetadata.TrackName == "" {
+			continue
+		}
+
+		artistNames := make([]string, len(e.TrackMetadata.Artists))
+		for i, a := range e.TrackMetadata.Artists {
+			artistNames[i] = a.Name
+		}
+
+		entries = append(entries, ChartEntry{
+			Rank:         e.ChartEntryData.CurrentRank,
+			Title:        e.TrackMetadata.TrackName,
+			Artist:       strings.Join(artistNames, ", "),
+			LastWeekRank: e.ChartEntryData.PreviousRank,
+			PeakRank:     e.ChartEntryData.PeakRank,
+			WeeksOnChart: e.ChartEntryData.AppearancesOnChart,
+			IsNew:        e.ChartEntryData.PreviousRank == 0,
+		})
+	}
+
+	return entries, nil
+}
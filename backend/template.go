@@ -0,0 +1,459 @@
+package backend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TrackFields carries everything a filename/folder Template can reference
+// by token. Not every field needs to be populated — unset string fields
+// simply render as empty, and unset numeric fields (Track, Disc) are
+// treated as "not present" rather than zero.
+type TrackFields struct {
+	Title       string
+	Artist      string
+	Album       string
+	AlbumArtist string
+	ReleaseDate string // YYYY-MM-DD; {year} is derived from this
+	Playlist    string
+	Creator     string
+	Track       int
+	Disc        int
+	ISRC        string
+	Explicit    bool
+	Quality     string
+	Codec       string
+	Ext         string // defaults to "flac" when empty
+}
+
+// knownTemplateTokens is the set of token names ParseTemplate accepts.
+// album_artist is kept as an alias of albumartist for backward
+// compatibility with the original flat-substitution format.
+var knownTemplateTokens = map[string]bool{
+	"title":        true,
+	"artist":       true,
+	"album":        true,
+	"albumartist":  true,
+	"album_artist": true,
+	"year":         true,
+	"playlist":     true,
+	"creator":      true,
+	"disc":         true,
+	"track":        true,
+	"isrc":         true,
+	"explicit":     true,
+	"quality":      true,
+	"codec":        true,
+	"ext":          true,
+}
+
+// numericTemplateTokens are the tokens whose value is a number, so a
+// numeric modifier (e.g. {track:03}) means zero-padding rather than
+// truncation.
+var numericTemplateTokens = map[string]bool{
+	"disc":  true,
+	"track": true,
+}
+
+// Template is a pre-compiled filename/folder-path format, produced by
+// ParseTemplate and reused across Render calls.
+type Template struct {
+	nodes []templateNode
+}
+
+type templateNode interface{}
+
+type literalNode struct {
+	text string
+}
+
+type templateModifier struct {
+	transform string // "", "upper", "lower", "title"
+	width     int
+	hasWidth  bool
+}
+
+type tokenNode struct {
+	name     string
+	modifier templateModifier
+}
+
+// choiceNode renders the first non-empty token among names, applying
+// modifier to whichever one is chosen. It implements tokens like
+// {albumartist|artist}.
+type choiceNode struct {
+	names    []string
+	modifier templateModifier
+}
+
+// conditionalGroupNode renders its nodes normally, but vanishes entirely
+// (contributing nothing to the output) if any token or choice inside it
+// resolves to an empty value. It implements groups like "[{disc}-]".
+type conditionalGroupNode struct {
+	nodes []templateNode
+}
+
+// ParseTemplate compiles a filename/folder-path format string into a
+// Template. It supports:
+//
+//	{token}             plain substitution
+//	{track:03}          zero-padded width (numeric tokens: track, disc)
+//	{title:60}          truncation to N runes (string tokens)
+//	{artist:upper}      upper/lower/title case transforms
+//	[{disc}-]           conditional group, vanishes if {disc} is empty
+//	{albumartist|artist} first non-empty token wins
+//	{{ and }}           literal brace escapes
+//
+// Unknown tokens are rejected so typos surface at format-compile time
+// instead of silently rendering as empty strings.
+func ParseTemplate(format string) (*Template, error) {
+	nodes, rest, err := parseTemplateNodes(format, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("template: unexpected ']' with no matching '['")
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+func parseTemplateNodes(s string, insideGroup bool) ([]templateNode, string, error) {
+	var nodes []templateNode
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			nodes = append(nodes, literalNode{text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for len(s) > 0 {
+		switch s[0] {
+		case ']':
+			if insideGroup {
+				flushLiteral()
+				return nodes, s[1:], nil
+			}
+			return nil, "", fmt.Errorf("template: unexpected ']' with no matching '['")
+
+		case '[':
+			flushLiteral()
+			subNodes, rest, err := parseTemplateNodes(s[1:], true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, conditionalGroupNode{nodes: subNodes})
+			s = rest
+			continue
+
+		case '{':
+			if strings.HasPrefix(s, "{{") {
+				literal.WriteByte('{')
+				s = s[2:]
+				continue
+			}
+			end := strings.IndexByte(s, '}')
+			if end == -1 {
+				return nil, "", fmt.Errorf("template: unterminated '{' in format")
+			}
+			flushLiteral()
+			node, err := parseTemplateToken(s[1:end])
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node)
+			s = s[end+1:]
+			continue
+
+		case '}':
+			if strings.HasPrefix(s, "}}") {
+				literal.WriteByte('}')
+				s = s[2:]
+				continue
+			}
+			return nil, "", fmt.Errorf("template: unexpected '}' with no matching '{'")
+
+		default:
+			literal.WriteByte(s[0])
+			s = s[1:]
+		}
+	}
+
+	if insideGroup {
+		return nil, "", fmt.Errorf("template: unterminated '[' in format")
+	}
+	flushLiteral()
+	return nodes, "", nil
+}
+
+func parseTemplateToken(content string) (templateNode, error) {
+	if strings.Contains(content, "|") {
+		parts := strings.Split(content, "|")
+		modifier := templateModifier{}
+		names := make([]string, len(parts))
+		for i, part := range parts {
+			name, mod, err := splitTokenModifier(part)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkKnownToken(name); err != nil {
+				return nil, err
+			}
+			names[i] = name
+			if i == len(parts)-1 {
+				modifier = mod
+			}
+		}
+		return choiceNode{names: names, modifier: modifier}, nil
+	}
+
+	name, modifier, err := splitTokenModifier(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKnownToken(name); err != nil {
+		return nil, err
+	}
+	return tokenNode{name: name, modifier: modifier}, nil
+}
+
+func checkKnownToken(name string) error {
+	if !knownTemplateTokens[name] {
+		return fmt.Errorf("template: unknown token {%s}", name)
+	}
+	return nil
+}
+
+func splitTokenModifier(part string) (name string, modifier templateModifier, err error) {
+	idx := strings.IndexByte(part, ':')
+	if idx == -1 {
+		return part, templateModifier{}, nil
+	}
+
+	name = part[:idx]
+	modStr := part[idx+1:]
+
+	switch modStr {
+	case "upper", "lower", "title":
+		return name, templateModifier{transform: modStr}, nil
+	}
+
+	width, convErr := strconv.Atoi(modStr)
+	if convErr != nil {
+		return "", templateModifier{}, fmt.Errorf("template: unknown modifier %q on {%s}", modStr, name)
+	}
+	if width <= 0 {
+		return "", templateModifier{}, fmt.Errorf("template: modifier width must be positive, got %d on {%s}", width, name)
+	}
+	return name, templateModifier{width: width, hasWidth: true}, nil
+}
+
+// Render expands the template against fields, reusing the same
+// SanitizeFilename rules the rest of the download pipeline uses for
+// individual path components.
+func (t *Template) Render(fields TrackFields) string {
+	out, _ := renderTemplateNodes(t.nodes, fields)
+	return out
+}
+
+func renderTemplateNodes(nodes []templateNode, fields TrackFields) (string, bool) {
+	var buf strings.Builder
+	anyEmpty := false
+
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case literalNode:
+			buf.WriteString(v.text)
+
+		case tokenNode:
+			value, empty := resolveTemplateToken(v.name, fields)
+			buf.WriteString(applyTemplateModifier(v.name, value, v.modifier))
+			if empty {
+				anyEmpty = true
+			}
+
+		case choiceNode:
+			value, empty := resolveTemplateChoice(v.names, fields)
+			name := v.names[len(v.names)-1]
+			for _, candidate := range v.names {
+				if _, candidateEmpty := resolveTemplateToken(candidate, fields); !candidateEmpty {
+					name = candidate
+					break
+				}
+			}
+			buf.WriteString(applyTemplateModifier(name, value, v.modifier))
+			if empty {
+				anyEmpty = true
+			}
+
+		case conditionalGroupNode:
+			sub, subAnyEmpty := renderTemplateNodes(v.nodes, fields)
+			if !subAnyEmpty {
+				buf.WriteString(sub)
+			}
+		}
+	}
+
+	return buf.String(), anyEmpty
+}
+
+func resolveTemplateToken(name string, fields TrackFields) (value string, empty bool) {
+	switch name {
+	case "title":
+		value = SanitizeFilename(fields.Title)
+	case "artist":
+		value = SanitizeFilename(fields.Artist)
+	case "album":
+		value = SanitizeFilename(fields.Album)
+	case "albumartist", "album_artist":
+		value = SanitizeFilename(fields.AlbumArtist)
+	case "year":
+		if len(fields.ReleaseDate) >= 4 {
+			value = fields.ReleaseDate[:4]
+		}
+	case "playlist":
+		value = SanitizeFilename(fields.Playlist)
+	case "creator":
+		value = SanitizeFilename(fields.Creator)
+	case "disc":
+		if fields.Disc > 0 {
+			value = strconv.Itoa(fields.Disc)
+		}
+	case "track":
+		if fields.Track > 0 {
+			value = strconv.Itoa(fields.Track)
+		}
+	case "isrc":
+		value = SanitizeFilename(fields.ISRC)
+	case "explicit":
+		if fields.Explicit {
+			value = "Explicit"
+		}
+	case "quality":
+		value = SanitizeFilename(fields.Quality)
+	case "codec":
+		value = SanitizeFilename(fields.Codec)
+	case "ext":
+		value = fields.Ext
+		if value == "" {
+			value = "flac"
+		}
+	}
+
+	return value, value == ""
+}
+
+func resolveTemplateChoice(names []string, fields TrackFields) (value string, empty bool) {
+	for _, name := range names {
+		if value, empty = resolveTemplateToken(name, fields); !empty {
+			return value, false
+		}
+	}
+	return "", true
+}
+
+func applyTemplateModifier(name, value string, m templateModifier) string {
+	if value == "" {
+		return value
+	}
+
+	if m.hasWidth {
+		if numericTemplateTokens[name] {
+			if n, err := strconv.Atoi(value); err == nil {
+				value = fmt.Sprintf("%0*d", m.width, n)
+			}
+		} else if utf8.RuneCountInString(value) > m.width {
+			runes := []rune(value)
+			value = string(runes[:m.width])
+		}
+	}
+
+	switch m.transform {
+	case "upper":
+		value = strings.ToUpper(value)
+	case "lower":
+		value = strings.ToLower(value)
+	case "title":
+		value = templateTitleCase(value)
+	}
+
+	return value
+}
+
+// RenderFilename parses and renders format as a filename template,
+// appending the track's extension (or "flac" if unset and the format
+// doesn't already place {ext} itself).
+func RenderFilename(format string, fields TrackFields) (string, error) {
+	tmpl, err := ParseTemplate(format)
+	if err != nil {
+		return "", err
+	}
+
+	name := tmpl.Render(fields)
+	if tmpl.hasToken("ext") {
+		return name, nil
+	}
+
+	ext := fields.Ext
+	if ext == "" {
+		ext = "flac"
+	}
+	return name + "." + ext, nil
+}
+
+// hasToken reports whether format places name as a token anywhere in the
+// template (including inside conditional groups or as part of a choice
+// like {ext|codec}), regardless of what modifier it carries.
+func (t *Template) hasToken(name string) bool {
+	return templateNodesHaveToken(t.nodes, name)
+}
+
+func templateNodesHaveToken(nodes []templateNode, name string) bool {
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case tokenNode:
+			if v.name == name {
+				return true
+			}
+		case choiceNode:
+			for _, choice := range v.names {
+				if choice == name {
+					return true
+				}
+			}
+		case conditionalGroupNode:
+			if templateNodesHaveToken(v.nodes, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RenderFolderPath parses and renders format as a folder-path template,
+// separately from the filename template, so albums/singles/playlists can
+// be organized into different directory layouts. The result is passed
+// through SanitizeFolderPath so it is safe to join onto a base download
+// directory.
+func RenderFolderPath(format string, fields TrackFields) (string, error) {
+	tmpl, err := ParseTemplate(format)
+	if err != nil {
+		return "", err
+	}
+	return SanitizeFolderPath(tmpl.Render(fields)), nil
+}
+
+func templateTitleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		if r == utf8.RuneError {
+			continue
+		}
+		words[i] = strings.ToUpper(string(r)) + strings.ToLower(w[size:])
+	}
+	return strings.Join(words, " ")
+}
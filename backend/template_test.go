@@ -0,0 +1,113 @@
+package backend
+
+import "testing"
+
+func TestTemplateRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		fields TrackFields
+		want   string
+	}{
+		{
+			name:   "conditional group renders when token present",
+			format: "{title}[ (Disc {disc})]",
+			fields: TrackFields{Title: "Song", Disc: 2},
+			want:   "Song (Disc 2)",
+		},
+		{
+			name:   "conditional group vanishes when token empty",
+			format: "{title}[ (Disc {disc})]",
+			fields: TrackFields{Title: "Song"},
+			want:   "Song",
+		},
+		{
+			name:   "choice falls back to next token when first is empty",
+			format: "{year|track}",
+			fields: TrackFields{Track: 5},
+			want:   "5",
+		},
+		{
+			name:   "choice picks first non-empty token over later ones",
+			format: "{year|track}",
+			fields: TrackFields{ReleaseDate: "2020-01-01", Track: 5},
+			want:   "2020",
+		},
+		{
+			name:   "numeric width modifier zero-pads",
+			format: "{track:03}",
+			fields: TrackFields{Track: 7},
+			want:   "007",
+		},
+		{
+			name:   "string width modifier truncates to N runes",
+			format: "{title:4}",
+			fields: TrackFields{Title: "Symphony"},
+			want:   "Symp",
+		},
+		{
+			name:   "transform modifier upper-cases the value",
+			format: "{artist:upper}",
+			fields: TrackFields{Artist: "nova tide"},
+			want:   "NOVA TIDE",
+		},
+		{
+			name:   "escaped braces render as literal braces",
+			format: "{{{title}}}",
+			fields: TrackFields{Title: "Song"},
+			want:   "{Song}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.format)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+			if got := tmpl.Render(tt.fields); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFilenameExtensionHandling(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		fields TrackFields
+		want   string
+	}{
+		{
+			name:   "appends the track's extension when format omits {ext}",
+			format: "{title} - {artist}",
+			fields: TrackFields{Title: "Song", Artist: "Artist", Ext: "mp3"},
+			want:   "Song - Artist.mp3",
+		},
+		{
+			name:   "does not double extension when format places {ext} plainly",
+			format: "{title} - {artist}.{ext}",
+			fields: TrackFields{Title: "Song", Artist: "Artist", Ext: "mp3"},
+			want:   "Song - Artist.mp3",
+		},
+		{
+			name:   "does not double extension when {ext} carries a modifier",
+			format: "{title} - {artist}.{ext:upper}",
+			fields: TrackFields{Title: "Song", Artist: "Artist", Ext: "mp3"},
+			want:   "Song - Artist.MP3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderFilename(tt.format, tt.fields)
+			if err != nil {
+				t.Fatalf("RenderFilename() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderFilename() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}